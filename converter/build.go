@@ -0,0 +1,166 @@
+package converter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chart"
+	"sigs.k8s.io/yaml"
+)
+
+// buildChartSource assembles a chart's source tree - Chart.yaml, values.yaml
+// plus values.schema.json, resolved dependencies, an applied starter if any,
+// and the split resource templates with shared helpers - under a scratch
+// directory and returns its path along with a cleanup func the caller must
+// defer. Building to a real directory (rather than purely in memory) keeps
+// this reusable by both the tarball-packaging path and the in-memory
+// loader.LoadDir path.
+func buildChartSource(manifestContent, chartName, chartVersion string, dependencies []*Dependency, options *ConvertOptions) (string, func(), error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to get user home directory")
+	}
+
+	tempDir := filepath.Join(homeDir, ".meshery", "tmp", "helm")
+	if err := os.MkdirAll(tempDir, 0755); err != nil {
+		return "", nil, errors.Wrap(err, "failed to create temp directory")
+	}
+
+	buildID := uuid.New().String()
+	buildDir := filepath.Join(tempDir, buildID)
+	chartSourcePath := filepath.Join(buildDir, chartName)
+
+	cleanup := func() {
+		if err := os.RemoveAll(buildDir); err != nil {
+			fmt.Printf("Warning: Failed to clean up build directory: %+v\n", errors.Wrap(err, "failed to remove build directory"))
+		}
+	}
+
+	if err := os.MkdirAll(chartSourcePath, 0755); err != nil {
+		cleanup()
+		return "", nil, errors.Wrap(err, "failed to create chart source directory")
+	}
+
+	if err := populateChartSource(chartSourcePath, manifestContent, chartName, chartVersion, dependencies, options); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return chartSourcePath, cleanup, nil
+}
+
+func populateChartSource(chartSourcePath, manifestContent, chartName, chartVersion string, dependencies []*Dependency, options *ConvertOptions) error {
+	templatesDir := filepath.Join(chartSourcePath, "templates")
+	if err := os.MkdirAll(templatesDir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create templates directory")
+	}
+
+	starterDir, err := resolveStarterDir(options)
+	if err != nil {
+		return err
+	}
+	if starterDir != "" {
+		if err := applyStarter(chartSourcePath, starterDir, chartName, chartVersion, options.Values); err != nil {
+			return errors.Wrap(err, "failed to apply starter "+options.Starter)
+		}
+		fmt.Printf("Applied starter %s from %s\n", options.Starter, starterDir)
+	}
+
+	chartMeta := &chart.Metadata{
+		APIVersion:  "v2",
+		Name:        chartName,
+		Version:     chartVersion,
+		Description: fmt.Sprintf("Helm chart for '%s' generated by Meshery", chartName),
+		Type:        "application",
+	}
+
+	for _, dep := range dependencies {
+		chartMeta.Dependencies = append(chartMeta.Dependencies, dep.toChartDependency())
+	}
+
+	chartYamlContent, err := yaml.Marshal(chartMeta)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal Chart.yaml metadata")
+	}
+
+	if err := os.WriteFile(filepath.Join(chartSourcePath, "Chart.yaml"), chartYamlContent, 0644); err != nil {
+		return errors.Wrap(err, "failed to write Chart.yaml")
+	}
+
+	if len(dependencies) > 0 {
+		if err := resolveDependencies(chartSourcePath); err != nil {
+			return err
+		}
+	}
+
+	parameterizedManifest, extractedValues, err := parameterizeManifest(manifestContent, ParameterizationRules)
+	if err != nil {
+		return errors.Wrap(err, "failed to parameterize manifest")
+	}
+
+	values := map[string]interface{}{
+		"global": map[string]interface{}{
+			"namespace": "default",
+		},
+	}
+	for key, value := range extractedValues {
+		values[key] = value
+	}
+
+	valuesPath := filepath.Join(chartSourcePath, "values.yaml")
+	if _, err := os.Stat(valuesPath); os.IsNotExist(err) {
+		valuesContent, err := yaml.Marshal(values)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal values.yaml")
+		}
+		valuesContent = append([]byte("# Default values for "+chartName+"\n"), valuesContent...)
+		if err := os.WriteFile(valuesPath, valuesContent, 0644); err != nil {
+			return errors.Wrap(err, "failed to write values.yaml")
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "failed to check for starter-provided values.yaml")
+	}
+
+	schemaPath := filepath.Join(chartSourcePath, "values.schema.json")
+	if _, err := os.Stat(schemaPath); os.IsNotExist(err) {
+		schemaContent, err := generateValuesSchema(values)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(schemaPath, schemaContent, 0644); err != nil {
+			return errors.Wrap(err, "failed to write values.schema.json")
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "failed to check for starter-provided values.schema.json")
+	}
+
+	resourceTemplates, err := splitResourceTemplates(parameterizedManifest)
+	if err != nil {
+		return errors.Wrap(err, "failed to split manifest into resource templates")
+	}
+
+	for _, tmpl := range resourceTemplates {
+		templatePath := filepath.Join(templatesDir, tmpl.Filename)
+		if _, err := os.Stat(templatePath); os.IsNotExist(err) {
+			if err := os.WriteFile(templatePath, []byte(tmpl.Content), 0644); err != nil {
+				return errors.Wrap(err, "failed to write template: "+tmpl.Filename)
+			}
+		} else if err != nil {
+			return errors.Wrap(err, "failed to check for starter-provided template: "+tmpl.Filename)
+		}
+	}
+
+	helpersPath := filepath.Join(templatesDir, "_helpers.tpl")
+	if _, err := os.Stat(helpersPath); os.IsNotExist(err) {
+		if err := os.WriteFile(helpersPath, []byte(helpersTplContent), 0644); err != nil {
+			return errors.Wrap(err, "failed to write _helpers.tpl")
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "failed to check for starter-provided _helpers.tpl")
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const buildTestManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.21
+`
+
+func TestPopulateChartSource(t *testing.T) {
+	chartSourcePath := t.TempDir()
+
+	if err := populateChartSource(chartSourcePath, buildTestManifest, "sample", "0.1.0", nil, &ConvertOptions{}); err != nil {
+		t.Fatalf("populateChartSource returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		"Chart.yaml",
+		"values.yaml",
+		"values.schema.json",
+		filepath.Join("templates", "_helpers.tpl"),
+		filepath.Join("templates", "deployment-web.yaml"),
+	} {
+		if _, err := os.Stat(filepath.Join(chartSourcePath, want)); err != nil {
+			t.Errorf("expected %s to exist, got: %v", want, err)
+		}
+	}
+
+	deployment, err := os.ReadFile(filepath.Join(chartSourcePath, "templates", "deployment-web.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read generated deployment template: %v", err)
+	}
+	if !strings.Contains(string(deployment), `{{ .Values.web.replicaCount }}`) {
+		t.Errorf("expected deployment template to reference extracted replicaCount, got:\n%s", deployment)
+	}
+}
+
+// TestPopulateChartSourceKeepsStarterProvidedFiles guards the ordering bugs
+// 86455dd, 14d32ae, and 1382437 each had to fix after the fact - a starter's
+// own values.yaml and templates/<file>.yaml must survive untouched rather
+// than being silently overwritten by the generated equivalents.
+func TestPopulateChartSourceKeepsStarterProvidedFiles(t *testing.T) {
+	starterDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(starterDir, "templates"), 0755); err != nil {
+		t.Fatalf("failed to create starter templates dir: %v", err)
+	}
+
+	const starterValues = "serviceAccount:\n  create: false\n"
+	if err := os.WriteFile(filepath.Join(starterDir, "values.yaml"), []byte(starterValues), 0644); err != nil {
+		t.Fatalf("failed to write starter values.yaml: %v", err)
+	}
+
+	const starterDeployment = "# starter-managed, do not overwrite\n"
+	if err := os.WriteFile(filepath.Join(starterDir, "templates", "deployment-web.yaml"), []byte(starterDeployment), 0644); err != nil {
+		t.Fatalf("failed to write starter deployment template: %v", err)
+	}
+
+	chartSourcePath := t.TempDir()
+	options := &ConvertOptions{StarterDir: starterDir}
+	if err := populateChartSource(chartSourcePath, buildTestManifest, "sample", "0.1.0", nil, options); err != nil {
+		t.Fatalf("populateChartSource returned error: %v", err)
+	}
+
+	values, err := os.ReadFile(filepath.Join(chartSourcePath, "values.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read values.yaml: %v", err)
+	}
+	if string(values) != starterValues {
+		t.Errorf("expected starter-provided values.yaml to survive untouched, got:\n%s", values)
+	}
+
+	deployment, err := os.ReadFile(filepath.Join(chartSourcePath, "templates", "deployment-web.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read templates/deployment-web.yaml: %v", err)
+	}
+	if string(deployment) != starterDeployment {
+		t.Errorf("expected starter-provided templates/deployment-web.yaml to survive untouched, got:\n%s", deployment)
+	}
+}
@@ -0,0 +1,90 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/layer5io/meshkit/models/patterns"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/downloader"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/helmpath"
+)
+
+// dependencyAnnotation is the pattern-level annotation a design uses to
+// declare the sub-charts it wants materialized into the generated chart. Its
+// value is a JSON-encoded list of Dependency entries.
+const dependencyAnnotation = "meshery.io/helm-dependencies"
+
+// Dependency mirrors the fields Helm itself recognizes in a Chart.yaml
+// "dependencies" entry, so patterns can declare sub-charts without having to
+// know about helm.sh/helm/v3/pkg/chart.Dependency directly.
+type Dependency struct {
+	Name         string        `json:"name"`
+	Version      string        `json:"version"`
+	Repository   string        `json:"repository"`
+	Alias        string        `json:"alias,omitempty"`
+	Condition    string        `json:"condition,omitempty"`
+	Tags         []string      `json:"tags,omitempty"`
+	ImportValues []interface{} `json:"import-values,omitempty"`
+}
+
+func (d *Dependency) toChartDependency() *chart.Dependency {
+	return &chart.Dependency{
+		Name:         d.Name,
+		Version:      d.Version,
+		Repository:   d.Repository,
+		Alias:        d.Alias,
+		Condition:    d.Condition,
+		Tags:         d.Tags,
+		ImportValues: d.ImportValues,
+	}
+}
+
+// extractDependencies reads the dependencyAnnotation off the pattern, if
+// present, and decodes it into the sub-charts that should be pulled into the
+// generated chart.
+func extractDependencies(pattern *patterns.PatternFile) ([]*Dependency, error) {
+	raw, ok := pattern.Annotations[dependencyAnnotation]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+
+	var deps []*Dependency
+	if err := json.Unmarshal([]byte(raw), &deps); err != nil {
+		return nil, errors.Wrap(err, "failed to parse "+dependencyAnnotation+" annotation")
+	}
+
+	return deps, nil
+}
+
+// resolveDependencies fetches and verifies every dependency declared in
+// chartSourcePath/Chart.yaml into its charts/ directory and records the
+// resolved versions and digests in Chart.lock, the same way `helm dependency
+// update` would.
+func resolveDependencies(chartSourcePath string) error {
+	manager := &downloader.Manager{
+		Out:              os.Stdout,
+		ChartPath:        chartSourcePath,
+		Getters:          getter.All(cli.New()),
+		RepositoryConfig: helmpath.ConfigPath("repositories.yaml"),
+		RepositoryCache:  helmpath.CachePath("repository"),
+	}
+
+	if err := manager.Update(); err != nil {
+		return errors.Wrap(err, "failed to resolve helm chart dependencies")
+	}
+
+	lockPath := filepath.Join(chartSourcePath, "Chart.lock")
+	if _, err := os.Stat(lockPath); err != nil {
+		return errors.Wrap(err, "expected Chart.lock to be written after dependency resolution")
+	}
+
+	fmt.Printf("Resolved helm chart dependencies into %s\n", filepath.Join(chartSourcePath, "charts"))
+
+	return nil
+}
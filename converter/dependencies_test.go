@@ -0,0 +1,57 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/layer5io/meshkit/models/patterns"
+)
+
+func TestExtractDependencies(t *testing.T) {
+	pattern := &patterns.PatternFile{
+		Annotations: map[string]string{
+			dependencyAnnotation: `[{"name":"redis","version":"17.0.0","repository":"https://charts.bitnami.com/bitnami","condition":"redis.enabled"}]`,
+		},
+	}
+
+	deps, err := extractDependencies(pattern)
+	if err != nil {
+		t.Fatalf("extractDependencies returned error: %v", err)
+	}
+	if len(deps) != 1 {
+		t.Fatalf("expected 1 dependency, got %d", len(deps))
+	}
+
+	dep := deps[0]
+	if dep.Name != "redis" || dep.Version != "17.0.0" || dep.Repository != "https://charts.bitnami.com/bitnami" || dep.Condition != "redis.enabled" {
+		t.Errorf("unexpected dependency fields: %#v", dep)
+	}
+
+	chartDep := dep.toChartDependency()
+	if chartDep.Name != dep.Name || chartDep.Version != dep.Version || chartDep.Repository != dep.Repository || chartDep.Condition != dep.Condition {
+		t.Errorf("toChartDependency() did not preserve fields, got: %#v", chartDep)
+	}
+}
+
+func TestExtractDependenciesNoAnnotation(t *testing.T) {
+	pattern := &patterns.PatternFile{}
+
+	deps, err := extractDependencies(pattern)
+	if err != nil {
+		t.Fatalf("extractDependencies returned error: %v", err)
+	}
+	if deps != nil {
+		t.Errorf("expected no dependencies without the annotation, got %#v", deps)
+	}
+}
+
+func TestExtractDependenciesInvalidJSON(t *testing.T) {
+	pattern := &patterns.PatternFile{
+		Annotations: map[string]string{
+			dependencyAnnotation: `not json`,
+		},
+	}
+
+	if _, err := extractDependencies(pattern); err == nil {
+		t.Error("expected an error for a malformed dependency annotation, got nil")
+	}
+}
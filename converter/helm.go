@@ -7,132 +7,82 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/google/uuid"
 	"github.com/layer5io/meshkit/models/patterns"
 	"github.com/pkg/errors"
 	"helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/chart"
-	"sigs.k8s.io/yaml"
 )
 
 type HelmConverter struct{}
 
-func (h *HelmConverter) Convert(patternFile string) (string, error) {
-	pattern, err := patterns.GetPatternFormat(patternFile)
+func (h *HelmConverter) Convert(patternFile string, opts ...ConvertOption) (string, error) {
+	manifestContent, chartName, chartVersion, dependencies, options, err := h.prepare(patternFile, opts...)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to load pattern file: "+patternFile)
+		return "", err
 	}
 
-	patterns.ProcessAnnotations(pattern)
-
-	k8sConverter := K8sConverter{}
-	k8sManifest, err := k8sConverter.Convert(patternFile)
+	chartContent, err := createHelmChartContent(manifestContent, chartName, chartVersion, dependencies, options)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to convert to k8s manifest")
+		return "", errors.Wrap(err, "failed to create helm chart content")
 	}
 
-	fmt.Printf("K8s manifest generated, size: %d bytes\n", len(k8sManifest))
+	return chartContent, nil
+}
 
-	chartName := sanitizeHelmName(pattern.Name)
-	if chartName == "" {
-		chartName = pattern.Name
+// prepare loads patternFile, converts it to a k8s manifest, and resolves
+// the chart name/version/dependencies every conversion entry point needs.
+func (h *HelmConverter) prepare(patternFile string, opts ...ConvertOption) (manifestContent, chartName, chartVersion string, dependencies []*Dependency, options *ConvertOptions, err error) {
+	options = &ConvertOptions{}
+	for _, opt := range opts {
+		opt(options)
 	}
 
-	chartVersion := pattern.Version
-
-	chartContent, err := createHelmChartContent(k8sManifest, chartName, chartVersion)
+	pattern, err := patterns.GetPatternFormat(patternFile)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to create helm chart content")
+		return "", "", "", nil, nil, errors.Wrap(err, "failed to load pattern file: "+patternFile)
 	}
 
-	return chartContent, nil
-}
+	patterns.ProcessAnnotations(pattern)
 
-func createHelmChartContent(manifestContent, chartName, chartVersion string) (string, error) {
-	homeDir, err := os.UserHomeDir()
+	k8sConverter := K8sConverter{}
+	manifestContent, err = k8sConverter.Convert(patternFile)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to get user home directory")
+		return "", "", "", nil, nil, errors.Wrap(err, "failed to convert to k8s manifest")
 	}
 
-	mesheryDir := filepath.Join(homeDir, ".meshery")
-	packageDir := filepath.Join(mesheryDir, "helm-packages")
-	tempDir := filepath.Join(mesheryDir, "tmp", "helm")
-
-	if err := os.MkdirAll(packageDir, 0755); err != nil {
-		return "", errors.Wrap(err, "failed to create package directory")
-	}
+	fmt.Printf("K8s manifest generated, size: %d bytes\n", len(manifestContent))
 
-	if err := os.MkdirAll(tempDir, 0755); err != nil {
-		return "", errors.Wrap(err, "failed to create temp directory")
+	chartName = sanitizeHelmName(pattern.Name)
+	if chartName == "" {
+		chartName = pattern.Name
 	}
 
-	buildID := uuid.New().String()
-	buildDir := filepath.Join(tempDir, buildID)
-	chartSourcePath := filepath.Join(buildDir, chartName)
-
-	defer func() {
-		err := os.RemoveAll(buildDir)
-		if err != nil {
-			fmt.Printf("Warning: Failed to clean up build directory: %+v\n", errors.Wrap(err, "failed to remove build directory"))
-		}
-	}()
-	if err := os.MkdirAll(chartSourcePath, 0755); err != nil {
-		return "", errors.Wrap(err, "failed to create chart source directory")
-	}
+	chartVersion = pattern.Version
 
-	templatesDir := filepath.Join(chartSourcePath, "templates")
-	if err := os.MkdirAll(templatesDir, 0755); err != nil {
-		return "", errors.Wrap(err, "failed to create templates directory")
+	dependencies, err = extractDependencies(pattern)
+	if err != nil {
+		return "", "", "", nil, nil, errors.Wrap(err, "failed to extract helm chart dependencies")
 	}
 
-	chartMeta := &chart.Metadata{
-		APIVersion:  "v2",
-		Name:        chartName,
-		Version:     chartVersion,
-		Description: fmt.Sprintf("Helm chart for '%s' generated by Meshery", chartName),
-		Type:        "application",
-	}
+	return manifestContent, chartName, chartVersion, dependencies, options, nil
+}
 
-	chartYamlContent, err := yaml.Marshal(chartMeta)
+func createHelmChartContent(manifestContent, chartName, chartVersion string, dependencies []*Dependency, options *ConvertOptions) (string, error) {
+	chartSourcePath, cleanup, err := buildChartSource(manifestContent, chartName, chartVersion, dependencies, options)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to marshal Chart.yaml metadata")
+		return "", err
 	}
+	defer cleanup()
 
-	if err := os.WriteFile(filepath.Join(chartSourcePath, "Chart.yaml"), chartYamlContent, 0644); err != nil {
-		return "", errors.Wrap(err, "failed to write Chart.yaml")
-	}
-
-	valuesContent := []byte("# Default values for " + chartName + "\nglobal:\n  namespace: default\n")
-	if err := os.WriteFile(filepath.Join(chartSourcePath, "values.yaml"), valuesContent, 0644); err != nil {
-		return "", errors.Wrap(err, "failed to write values.yaml")
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get user home directory")
 	}
 
-	if err := os.WriteFile(filepath.Join(templatesDir, "manifest.yaml"), []byte(manifestContent), 0644); err != nil {
-		return "", errors.Wrap(err, "failed to write manifest.yaml")
+	packageDir := filepath.Join(homeDir, ".meshery", "helm-packages")
+	if err := os.MkdirAll(packageDir, 0755); err != nil {
+		return "", errors.Wrap(err, "failed to create package directory")
 	}
 
-// helpersContent := `{{/* Generate basic chart labels */}}
-// {{- define "chart.labels" }}
-// helm.sh/chart: {{ .Chart.Name }}-{{ .Chart.Version | replace "+" "_" | trunc 63 | trimSuffix "-" }}
-// app.kubernetes.io/managed-by: {{ .Release.Service }}
-// app.kubernetes.io/instance: {{ .Release.Name }}
-// app.kubernetes.io/name: {{ include "chart.name" . }}
-// {{- end }}
-
-// {{/* Define chart name */}}
-// {{- define "chart.name" }}
-// {{- default .Chart.Name .Values.nameOverride | trunc 63 | trimSuffix "-" }}
-// {{- end }}
-// `
-	// if err := os.WriteFile(filepath.Join(templatesDir, "_helpers.tpl"), []byte(helpersContent), 0644); err != nil {
-	// 	return "", errors.Wrap(err, "failed to write _helpers.tpl")
-	// }
-
-	// notesContent := fmt.Sprintf("This Helm chart '%s' was generated by Meshery.\n", chartName)
-	// if err := os.WriteFile(filepath.Join(chartSourcePath, "NOTES.txt"), []byte(notesContent), 0644); err != nil {
-	// 	return "", errors.Wrap(err, "failed to write NOTES.txt")
-	// }
-
 	packager := action.NewPackage()
 	packager.Destination = packageDir
 
@@ -150,8 +100,12 @@ func createHelmChartContent(manifestContent, chartName, chartVersion string) (st
 
 	fmt.Printf("Packaged chart size: %d bytes\n", len(chartData))
 
-	if err := os.Remove(packagedChartPath); err != nil {
-		fmt.Printf("Warning: Failed to clean up packaged chart: %+v\n", errors.Wrap(err, "failed to remove packaged chart"))	
+	repository, err := NewHelmRepository(packageDir)
+	if err != nil {
+		return "", err
+	}
+	if err := repository.reindex(); err != nil {
+		return "", errors.Wrap(err, "failed to index helm repository")
 	}
 
 	return string(chartData), nil
@@ -0,0 +1,268 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// FieldRule describes one configurable field a parameterization pass hoists
+// out of a rendered manifest and replaces with a {{ .Values.<path> }}
+// reference.
+type FieldRule struct {
+	// Kind restricts the rule to resources of this Kind; empty matches any
+	// resource.
+	Kind string
+	// Path walks the decoded resource down to the field to extract. "#"
+	// matches the first element of a list.
+	Path []string
+	// ValuesPath is the dot path under .Values the extracted value is
+	// written to. A single "%s" verb, if present, is filled in with the
+	// resource's sanitized metadata.name.
+	ValuesPath string
+	// Numeric coerces the extracted value to a Go int in values.yaml,
+	// instead of keeping it as the literal string scalar. Only set this for
+	// fields whose semantics this converter controls (e.g. replica counts) -
+	// coercing arbitrary strings (image tags, env values) silently mangles
+	// them (a leading-zero value such as "007" becomes 7).
+	Numeric bool
+}
+
+// ParameterizationRules is the default set of fields createHelmChartContent
+// extracts into values.yaml. Callers can append project-specific rules
+// before conversion to extend extraction without forking the converter.
+// Each rule matches one exact key path; keys cannot be matched by prefix, so
+// hoisting several annotations takes one rule per annotation key.
+var ParameterizationRules = []FieldRule{
+	{Kind: "Deployment", Path: []string{"spec", "replicas"}, ValuesPath: "%s.replicaCount", Numeric: true},
+	{Path: []string{"spec", "template", "spec", "containers", "#", "image"}, ValuesPath: "%s.image"},
+	{Path: []string{"spec", "template", "spec", "containers", "#", "resources", "limits", "cpu"}, ValuesPath: "%s.resources.limits.cpu"},
+	{Path: []string{"spec", "template", "spec", "containers", "#", "resources", "limits", "memory"}, ValuesPath: "%s.resources.limits.memory"},
+	{Path: []string{"spec", "template", "spec", "containers", "#", "resources", "requests", "cpu"}, ValuesPath: "%s.resources.requests.cpu"},
+	{Path: []string{"spec", "template", "spec", "containers", "#", "resources", "requests", "memory"}, ValuesPath: "%s.resources.requests.memory"},
+	{Kind: "Service", Path: []string{"spec", "type"}, ValuesPath: "%s.service.type"},
+	{Kind: "Ingress", Path: []string{"spec", "rules", "#", "host"}, ValuesPath: "%s.ingress.host"},
+}
+
+// parameterizeManifest replaces every field matched by rules across the
+// manifest's YAML documents with a {{ .Values.<path> }} reference, returning
+// the rewritten manifest alongside the defaults extracted into a nested
+// values map.
+func parameterizeManifest(manifestContent string, rules []FieldRule) (string, map[string]interface{}, error) {
+	docs := splitYAMLDocuments(manifestContent)
+	values := map[string]interface{}{}
+	rendered := make([]string, 0, len(docs))
+
+	for _, doc := range docs {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+
+		var root yaml.Node
+		if err := yaml.Unmarshal([]byte(doc), &root); err != nil {
+			return "", nil, errors.Wrap(err, "failed to parse manifest document for parameterization")
+		}
+		if len(root.Content) == 0 {
+			rendered = append(rendered, doc)
+			continue
+		}
+
+		docNode := root.Content[0]
+		kind := lookupScalar(docNode, []string{"kind"})
+		name := lookupScalar(docNode, []string{"metadata", "name"})
+		key := sanitizeValuesKey(name)
+
+		for _, rule := range rules {
+			if rule.Kind != "" && rule.Kind != kind {
+				continue
+			}
+
+			field, ok := findFieldNode(docNode, rule.Path)
+			if !ok {
+				continue
+			}
+
+			valuesPath := rule.ValuesPath
+			if strings.Contains(valuesPath, "%s") {
+				if key == "" {
+					continue
+				}
+				valuesPath = fmt.Sprintf(valuesPath, key)
+			}
+
+			var extracted interface{} = field.Value
+			if rule.Numeric {
+				extracted = scalarToInt(field)
+			}
+			setNestedValue(values, valuesPath, extracted)
+			setTemplateScalar(field, fmt.Sprintf("{{ .Values.%s }}", valuesPath), rule.Numeric)
+		}
+
+		extractEnvVars(docNode, key, values)
+
+		out, err := yaml.Marshal(&root)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to re-marshal parameterized manifest")
+		}
+		rendered = append(rendered, strings.TrimSuffix(stripRawTemplateMarkers(string(out)), "\n"))
+	}
+
+	return strings.Join(rendered, "\n---\n") + "\n", values, nil
+}
+
+// extractEnvVars hoists the literal (non-valueFrom) values of the first
+// container's env entries into "<key>.env.<name>".
+func extractEnvVars(docNode *yaml.Node, key string, values map[string]interface{}) {
+	envList, ok := findFieldNode(docNode, []string{"spec", "template", "spec", "containers", "#", "env"})
+	if !ok || envList.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for _, entry := range envList.Content {
+		if entry.Kind != yaml.MappingNode {
+			continue
+		}
+
+		name := lookupScalar(entry, []string{"name"})
+		valueNode := findChild(entry, "value")
+		if name == "" || valueNode == nil {
+			continue
+		}
+
+		valuesPath := fmt.Sprintf("%s.env.%s", key, sanitizeValuesKey(name))
+		setNestedValue(values, valuesPath, valueNode.Value)
+		setTemplateScalar(valueNode, fmt.Sprintf("{{ .Values.%s }}", valuesPath), false)
+	}
+}
+
+// findFieldNode walks a decoded resource node down path, where "#" selects
+// the first element of a sequence, returning the node at the end of the
+// path.
+func findFieldNode(node *yaml.Node, path []string) (*yaml.Node, bool) {
+	current := node
+	for _, segment := range path {
+		if segment == "#" {
+			if current.Kind != yaml.SequenceNode || len(current.Content) == 0 {
+				return nil, false
+			}
+			current = current.Content[0]
+			continue
+		}
+
+		child := findChild(current, segment)
+		if child == nil {
+			return nil, false
+		}
+		current = child
+	}
+	return current, true
+}
+
+func findChild(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func lookupScalar(node *yaml.Node, path []string) string {
+	field, ok := findFieldNode(node, path)
+	if !ok || field.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return field.Value
+}
+
+// scalarToInt coerces a scalar node to a Go int, falling back to its literal
+// string value if it isn't a valid integer. Only used for fields marked
+// FieldRule.Numeric.
+func scalarToInt(node *yaml.Node) interface{} {
+	if i, err := strconv.Atoi(node.Value); err == nil {
+		return i
+	}
+	return node.Value
+}
+
+func setNestedValue(values map[string]interface{}, path string, value interface{}) {
+	segments := strings.Split(path, ".")
+	current := values
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := current[segment].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[segment] = next
+		}
+		current = next
+	}
+	current[segments[len(segments)-1]] = value
+}
+
+// sanitizeValuesKey turns a k8s resource/env name into a valid Go template
+// identifier segment (camelCase, no dashes or dots).
+func sanitizeValuesKey(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '.' || r == '_'
+	})
+	if len(parts) == 0 {
+		return ""
+	}
+
+	key := parts[0]
+	for _, part := range parts[1:] {
+		if part == "" {
+			continue
+		}
+		key += strings.ToUpper(part[:1]) + part[1:]
+	}
+	return key
+}
+
+// generateValuesSchema produces a JSON Schema describing the shape of the
+// extracted values, so `helm install --set` and `helm lint` can validate
+// overrides against it.
+func generateValuesSchema(values map[string]interface{}) ([]byte, error) {
+	schema := map[string]interface{}{
+		"$schema":    "https://json-schema.org/draft-07/schema#",
+		"type":       "object",
+		"properties": schemaProperties(values),
+	}
+
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal values.schema.json")
+	}
+	return out, nil
+}
+
+func schemaProperties(values map[string]interface{}) map[string]interface{} {
+	properties := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		properties[key] = schemaForValue(value)
+	}
+	return properties
+}
+
+func schemaForValue(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": schemaProperties(v),
+		}
+	case int, int64, float64:
+		return map[string]interface{}{"type": "number"}
+	case bool:
+		return map[string]interface{}{"type": "boolean"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
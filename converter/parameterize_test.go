@@ -0,0 +1,92 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParameterizeManifest(t *testing.T) {
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.21
+        env:
+        - name: VERSION
+          value: "007"
+`
+
+	rendered, values, err := parameterizeManifest(manifest, ParameterizationRules)
+	if err != nil {
+		t.Fatalf("parameterizeManifest returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		`replicas: {{ .Values.web.replicaCount }}`,
+		`image: "{{ .Values.web.image }}"`,
+		`value: "{{ .Values.web.env.VERSION }}"`,
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected rendered manifest to contain %q, got:\n%s", want, rendered)
+		}
+	}
+
+	if strings.Contains(rendered, `"{{ .Values.web.replicaCount }}"`) {
+		t.Errorf("expected numeric replicaCount reference to stay unquoted so it renders as an int, got:\n%s", rendered)
+	}
+
+	web, ok := values["web"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected values[\"web\"] to be a map, got %#v", values["web"])
+	}
+
+	if replicaCount, ok := web["replicaCount"].(int); !ok || replicaCount != 3 {
+		t.Errorf("expected replicaCount to be coerced to int(3), got %#v", web["replicaCount"])
+	}
+
+	if image, ok := web["image"].(string); !ok || image != "nginx:1.21" {
+		t.Errorf("expected image to stay a literal string, got %#v", web["image"])
+	}
+
+	env, ok := web["env"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected web.env to be a map, got %#v", web["env"])
+	}
+	if version, ok := env["VERSION"].(string); !ok || version != "007" {
+		t.Errorf(`expected env var to keep its literal string value "007" (not be coerced to a number and lose the leading zero), got %#v`, env["VERSION"])
+	}
+}
+
+func TestParameterizeManifestKeepsPrecisionOfNumericLookingEnvValue(t *testing.T) {
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.21
+        env:
+        - name: RATIO
+          value: "1.10"
+`
+
+	_, values, err := parameterizeManifest(manifest, ParameterizationRules)
+	if err != nil {
+		t.Fatalf("parameterizeManifest returned error: %v", err)
+	}
+
+	web := values["web"].(map[string]interface{})
+	env := web["env"].(map[string]interface{})
+	if ratio, ok := env["RATIO"].(string); !ok || ratio != "1.10" {
+		t.Errorf(`expected env var "1.10" to be kept as-is, got %#v`, env["RATIO"])
+	}
+}
@@ -0,0 +1,94 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+)
+
+// LoadedChart builds the chart for patternFile the same way Convert does,
+// but returns the in-memory *chart.Chart instead of a packaged tarball, so
+// callers can hand it straight to action.Install/action.Upgrade without
+// packaging to disk and reading the tarball back.
+func (h *HelmConverter) LoadedChart(patternFile string, opts ...ConvertOption) (*chart.Chart, error) {
+	manifestContent, chartName, chartVersion, dependencies, options, err := h.prepare(patternFile, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	chartSourcePath, cleanup, err := buildChartSource(manifestContent, chartName, chartVersion, dependencies, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	loadedChart, err := loader.LoadDir(chartSourcePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load in-memory chart")
+	}
+
+	return loadedChart, nil
+}
+
+// Render builds the chart for patternFile and renders its templates through
+// Helm's own engine with the supplied values, returning the sorted, resolved
+// YAML - the way k8s orchestrators consume charts programmatically without
+// shelling out to `helm template`. Modeled on the render/filter steps
+// helm.sh/helm/v3/pkg/action's Install and Upgrade run before applying a
+// chart's manifests.
+func (h *HelmConverter) Render(patternFile string, values map[string]interface{}, releaseName, namespace string) (string, error) {
+	loadedChart, err := h.LoadedChart(patternFile)
+	if err != nil {
+		return "", err
+	}
+
+	releaseOptions := chartutil.ReleaseOptions{
+		Name:      releaseName,
+		Namespace: namespace,
+	}
+
+	renderValues, err := chartutil.ToRenderValues(loadedChart, values, releaseOptions, chartutil.DefaultCapabilities)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to compute render values")
+	}
+
+	rendered, err := engine.Render(loadedChart, renderValues)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to render chart templates")
+	}
+
+	return sortAndFilterRendered(rendered), nil
+}
+
+// sortAndFilterRendered drops NOTES.txt out of a Helm engine's rendered
+// template set - it's free-text release notes, not a manifest, and upstream
+// Helm excludes it from manifest output for the same reason (see
+// action.Install/Upgrade's renderResources in helm.sh/helm/v3/pkg/action) -
+// then concatenates what's left in a deterministic, source-sorted order.
+func sortAndFilterRendered(rendered map[string]string) string {
+	names := make([]string, 0, len(rendered))
+	for name := range rendered {
+		if strings.HasSuffix(name, "NOTES.txt") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var resolved strings.Builder
+	for _, name := range names {
+		content := strings.TrimSpace(rendered[name])
+		if content == "" {
+			continue
+		}
+		fmt.Fprintf(&resolved, "---\n# Source: %s\n%s\n", name, content)
+	}
+
+	return resolved.String()
+}
@@ -0,0 +1,48 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+)
+
+func TestSortAndFilterRendered(t *testing.T) {
+	testChart := &chart.Chart{
+		Metadata: &chart.Metadata{APIVersion: "v2", Name: "sample", Version: "0.1.0"},
+		Templates: []*chart.File{
+			{Name: "templates/service-web.yaml", Data: []byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n")},
+			{Name: "templates/deployment-web.yaml", Data: []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n")},
+			{Name: "templates/NOTES.txt", Data: []byte("Thank you for installing {{ .Chart.Name }}.")},
+		},
+		Values: map[string]interface{}{},
+	}
+
+	releaseOptions := chartutil.ReleaseOptions{Name: "sample", Namespace: "default"}
+	renderValues, err := chartutil.ToRenderValues(testChart, map[string]interface{}{}, releaseOptions, chartutil.DefaultCapabilities)
+	if err != nil {
+		t.Fatalf("ToRenderValues returned error: %v", err)
+	}
+
+	rendered, err := engine.Render(testChart, renderValues)
+	if err != nil {
+		t.Fatalf("engine.Render returned error: %v", err)
+	}
+
+	resolved := sortAndFilterRendered(rendered)
+
+	if strings.Contains(resolved, "Thank you for installing") {
+		t.Errorf("expected NOTES.txt to be excluded from resolved manifests, got:\n%s", resolved)
+	}
+
+	deploymentIdx := strings.Index(resolved, "# Source: sample/templates/deployment-web.yaml")
+	serviceIdx := strings.Index(resolved, "# Source: sample/templates/service-web.yaml")
+	if deploymentIdx == -1 || serviceIdx == -1 {
+		t.Fatalf("expected both resource sources in resolved manifests, got:\n%s", resolved)
+	}
+	if deploymentIdx > serviceIdx {
+		t.Errorf("expected resources sorted alphabetically by source name, got:\n%s", resolved)
+	}
+}
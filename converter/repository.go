@@ -0,0 +1,94 @@
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// HelmRepository maintains a directory of packaged chart tarballs as a
+// valid Helm chart repository - complete with an index.yaml - so a Meshery
+// instance can serve the designs it generates to `helm repo add`.
+type HelmRepository struct {
+	Dir string
+}
+
+// NewHelmRepository returns a HelmRepository rooted at dir, creating the
+// directory if it doesn't already exist.
+func NewHelmRepository(dir string) (*HelmRepository, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create helm repository directory")
+	}
+	return &HelmRepository{Dir: dir}, nil
+}
+
+// Publish writes chartBytes into the repository as a new tarball and
+// regenerates index.yaml to include it.
+func (r *HelmRepository) Publish(chartBytes []byte) error {
+	loadedChart, err := loader.LoadArchive(bytes.NewReader(chartBytes))
+	if err != nil {
+		return errors.Wrap(err, "failed to load chart archive")
+	}
+
+	filename := fmt.Sprintf("%s-%s.tgz", loadedChart.Metadata.Name, loadedChart.Metadata.Version)
+	if err := os.WriteFile(filepath.Join(r.Dir, filename), chartBytes, 0644); err != nil {
+		return errors.Wrap(err, "failed to write chart tarball")
+	}
+
+	return r.reindex()
+}
+
+// Index returns the repository's current index, generating it from the
+// tarballs on disk if index.yaml doesn't exist yet.
+func (r *HelmRepository) Index() (*repo.IndexFile, error) {
+	indexPath := filepath.Join(r.Dir, "index.yaml")
+	if _, err := os.Stat(indexPath); os.IsNotExist(err) {
+		if err := r.reindex(); err != nil {
+			return nil, err
+		}
+	}
+
+	index, err := repo.LoadIndexFile(indexPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load index.yaml")
+	}
+	return index, nil
+}
+
+// Handler serves the repository's tarballs and index.yaml over HTTP, ready
+// for `helm repo add` to consume directly.
+func (r *HelmRepository) Handler() http.Handler {
+	return http.FileServer(http.Dir(r.Dir))
+}
+
+// reindex regenerates index.yaml from every tarball in the repository,
+// merging with any index already on disk so versions that have since been
+// removed from the directory are still recorded.
+func (r *HelmRepository) reindex() error {
+	indexPath := filepath.Join(r.Dir, "index.yaml")
+
+	existing, err := repo.LoadIndexFile(indexPath)
+	if err != nil {
+		existing = repo.NewIndexFile()
+	}
+
+	current, err := repo.IndexDirectory(r.Dir, "")
+	if err != nil {
+		return errors.Wrap(err, "failed to index helm repository directory")
+	}
+
+	existing.Merge(current)
+	existing.SortEntries()
+
+	if err := existing.WriteFile(indexPath, 0644); err != nil {
+		return errors.Wrap(err, "failed to write index.yaml")
+	}
+
+	return nil
+}
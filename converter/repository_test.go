@@ -0,0 +1,65 @@
+package converter
+
+import (
+	"os"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+func TestHelmRepositoryPublishAndIndex(t *testing.T) {
+	chartPath, err := chartutil.Create("sample", t.TempDir())
+	if err != nil {
+		t.Fatalf("chartutil.Create returned error: %v", err)
+	}
+
+	packager := action.NewPackage()
+	packager.Destination = t.TempDir()
+	tarballPath, err := packager.Run(chartPath, nil)
+	if err != nil {
+		t.Fatalf("packager.Run returned error: %v", err)
+	}
+
+	tarballData, err := os.ReadFile(tarballPath)
+	if err != nil {
+		t.Fatalf("failed to read packaged chart: %v", err)
+	}
+
+	repository, err := NewHelmRepository(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewHelmRepository returned error: %v", err)
+	}
+
+	if err := repository.Publish(tarballData); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+
+	index, err := repository.Index()
+	if err != nil {
+		t.Fatalf("Index returned error: %v", err)
+	}
+
+	entries, ok := index.Entries["sample"]
+	if !ok || len(entries) != 1 {
+		t.Fatalf("expected one indexed version of chart %q, got entries: %#v", "sample", index.Entries)
+	}
+	if entries[0].Version != "0.1.0" {
+		t.Errorf("expected indexed version 0.1.0, got %q", entries[0].Version)
+	}
+}
+
+func TestHelmRepositoryIndexGeneratesOnFirstAccess(t *testing.T) {
+	repository, err := NewHelmRepository(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewHelmRepository returned error: %v", err)
+	}
+
+	index, err := repository.Index()
+	if err != nil {
+		t.Fatalf("Index returned error: %v", err)
+	}
+	if len(index.Entries) != 0 {
+		t.Errorf("expected an empty index for a repository with no tarballs, got entries: %#v", index.Entries)
+	}
+}
@@ -0,0 +1,131 @@
+package converter
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// chartNameToken is the placeholder starter authors use in scaffold
+// filenames and file bodies; it is substituted with the generated chart's
+// name before the scaffold is merged into the chart.
+const chartNameToken = "<CHARTNAME>"
+
+// ConvertOptions customizes how HelmConverter.Convert builds a chart.
+type ConvertOptions struct {
+	// Starter is the name of a scaffold directory under
+	// "~/.meshery/starters/<name>" to seed the generated chart with. Ignored
+	// if StarterDir is set.
+	Starter string
+	// StarterDir overrides the default "~/.meshery/starters" lookup with an
+	// explicit path to a scaffold directory.
+	StarterDir string
+	// Values are merged into the scaffold's variable substitution pass, in
+	// addition to the chart's own name and version.
+	Values map[string]interface{}
+}
+
+// ConvertOption mutates a ConvertOptions; pass zero or more to
+// HelmConverter.Convert.
+type ConvertOption func(*ConvertOptions)
+
+// WithStarter selects a named scaffold to seed the generated chart with. If
+// dir is non-empty it is used verbatim instead of resolving name under
+// "~/.meshery/starters".
+func WithStarter(name, dir string) ConvertOption {
+	return func(o *ConvertOptions) {
+		o.Starter = name
+		o.StarterDir = dir
+	}
+}
+
+// WithValues supplies additional variables available for substitution in
+// starter file bodies and filenames, alongside the chart's own name/version.
+func WithValues(values map[string]interface{}) ConvertOption {
+	return func(o *ConvertOptions) {
+		o.Values = values
+	}
+}
+
+// resolveStarterDir returns the on-disk scaffold directory for the given
+// options, or "" if no starter was requested.
+func resolveStarterDir(opts *ConvertOptions) (string, error) {
+	if opts.StarterDir != "" {
+		return opts.StarterDir, nil
+	}
+
+	if opts.Starter == "" {
+		return "", nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get user home directory")
+	}
+
+	return filepath.Join(homeDir, ".meshery", "starters", opts.Starter), nil
+}
+
+// applyStarter copies every file from starterDir into chartSourcePath,
+// substituting chartNameToken and any caller-supplied values in both
+// filenames and file bodies, the way `helm create --starter` seeds a new
+// chart from a named scaffold.
+func applyStarter(chartSourcePath, starterDir, chartName, chartVersion string, values map[string]interface{}) error {
+	info, err := os.Stat(starterDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to read starter directory: "+starterDir)
+	}
+	if !info.IsDir() {
+		return errors.New("starter path is not a directory: " + starterDir)
+	}
+
+	substitutions := map[string]string{
+		chartNameToken:  chartName,
+		"<CHARTVERSION>": chartVersion,
+	}
+	for key, value := range values {
+		substitutions["<"+strings.ToUpper(key)+">"] = fmt.Sprintf("%v", value)
+	}
+
+	return filepath.WalkDir(starterDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(starterDir, path)
+		if err != nil {
+			return errors.Wrap(err, "failed to compute relative starter path")
+		}
+		if relPath == "." {
+			return nil
+		}
+
+		destPath := filepath.Join(chartSourcePath, substituteTokens(relPath, substitutions))
+
+		if d.IsDir() {
+			return os.MkdirAll(destPath, 0755)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, "failed to read starter file: "+path)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return errors.Wrap(err, "failed to create starter destination directory")
+		}
+
+		return os.WriteFile(destPath, []byte(substituteTokens(string(content), substitutions)), 0644)
+	})
+}
+
+func substituteTokens(s string, substitutions map[string]string) string {
+	for token, value := range substitutions {
+		s = strings.ReplaceAll(s, token, value)
+	}
+	return s
+}
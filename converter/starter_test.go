@@ -0,0 +1,54 @@
+package converter
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveStarterDir(t *testing.T) {
+	dir, err := resolveStarterDir(&ConvertOptions{StarterDir: "/tmp/explicit-starter"})
+	if err != nil {
+		t.Fatalf("resolveStarterDir returned error: %v", err)
+	}
+	if dir != "/tmp/explicit-starter" {
+		t.Errorf("expected an explicit StarterDir to win, got %q", dir)
+	}
+
+	dir, err = resolveStarterDir(&ConvertOptions{})
+	if err != nil {
+		t.Fatalf("resolveStarterDir returned error: %v", err)
+	}
+	if dir != "" {
+		t.Errorf("expected no starter requested to resolve to an empty dir, got %q", dir)
+	}
+
+	dir, err = resolveStarterDir(&ConvertOptions{Starter: "org-default"})
+	if err != nil {
+		t.Fatalf("resolveStarterDir returned error: %v", err)
+	}
+	if !strings.HasSuffix(dir, filepath.Join(".meshery", "starters", "org-default")) {
+		t.Errorf("expected named starter to resolve under ~/.meshery/starters, got %q", dir)
+	}
+}
+
+func TestSubstituteTokens(t *testing.T) {
+	substitutions := map[string]string{
+		chartNameToken:    "sample",
+		"<CHARTVERSION>": "0.1.0",
+		"<REPLICAS>":      "3",
+	}
+
+	got := substituteTokens("name: <CHARTNAME>, version: <CHARTVERSION>, replicas: <REPLICAS>", substitutions)
+	want := "name: sample, version: 0.1.0, replicas: 3"
+	if got != want {
+		t.Errorf("substituteTokens() = %q, want %q", got, want)
+	}
+}
+
+func TestSubstituteTokensNoMatches(t *testing.T) {
+	got := substituteTokens("nothing to replace here", map[string]string{chartNameToken: "sample"})
+	if got != "nothing to replace here" {
+		t.Errorf("expected unmatched text to pass through unchanged, got %q", got)
+	}
+}
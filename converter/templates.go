@@ -0,0 +1,216 @@
+package converter
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// chartLabelsPlaceholder is written as a throwaway key in metadata.labels so
+// it can be swapped for a "{{- include "chart.labels" . | nindent N }}"
+// template directive after marshaling - a directive can't be represented as
+// a YAML node since it isn't a valid key/value pair. N is derived from the
+// placeholder line's own indentation, since yaml.v3's indent width isn't
+// guaranteed to match the "nindent 4" every other Helm chart hand-writes.
+const chartLabelsPlaceholder = "__MESHKIT_CHART_LABELS__"
+
+var chartLabelsPlaceholderLine = regexp.MustCompile(`(?m)^(\s*)` + chartLabelsPlaceholder + `: (?:""|null)\s*$`)
+
+// resourceTemplate is one k8s resource extracted from a pattern's rendered
+// manifest, destined for its own templates/<kind>-<name>.yaml file.
+type resourceTemplate struct {
+	Kind     string
+	Name     string
+	Filename string
+	Content  string
+}
+
+// splitResourceTemplates parses manifestContent's YAML documents, merges
+// each resource's labels with the chart's common labels and rewrites its
+// namespace to track the release, and returns one resourceTemplate per
+// document.
+func splitResourceTemplates(manifestContent string) ([]resourceTemplate, error) {
+	docs := splitYAMLDocuments(manifestContent)
+	templates := make([]resourceTemplate, 0, len(docs))
+	usedFilenames := make(map[string]int, len(docs))
+	// A bare "key: {{ ... }}" value left by a FieldRule.Numeric rewrite would
+	// parse as a YAML flow mapping; re-quote it behind rawTemplateMarker so it
+	// survives the round trip, then strip the quotes again after marshaling.
+	bareTemplateValue := regexp.MustCompile(`(?m)(: )(\{\{.*\}\})[ \t]*$`)
+
+	for _, doc := range docs {
+		doc = bareTemplateValue.ReplaceAllString(doc, `$1"`+rawTemplateMarker+`$2"`)
+
+		var root yaml.Node
+		if err := yaml.Unmarshal([]byte(doc), &root); err != nil {
+			return nil, errors.Wrap(err, "failed to parse manifest document for templating")
+		}
+		if len(root.Content) == 0 {
+			continue
+		}
+
+		docNode := root.Content[0]
+		kind := lookupScalar(docNode, []string{"kind"})
+		name := lookupScalar(docNode, []string{"metadata", "name"})
+
+		mergeChartLabels(docNode)
+		if !clusterScopedKinds[kind] {
+			templatizeNamespace(docNode)
+		}
+
+		rendered, err := yaml.Marshal(&root)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to re-marshal resource template")
+		}
+
+		content := chartLabelsPlaceholderLine.ReplaceAllStringFunc(stripRawTemplateMarkers(string(rendered)), func(match string) string {
+			indent := chartLabelsPlaceholderLine.FindStringSubmatch(match)[1]
+			return fmt.Sprintf(`%s{{- include "chart.labels" . | nindent %d }}`, indent, len(indent))
+		})
+
+		templates = append(templates, resourceTemplate{
+			Kind:     kind,
+			Name:     name,
+			Filename: uniqueResourceFilename(resourceFilename(kind, name), usedFilenames),
+			Content:  content,
+		})
+	}
+
+	return templates, nil
+}
+
+func resourceFilename(kind, name string) string {
+	kindPart := strings.ToLower(kind)
+	if kindPart == "" {
+		kindPart = "resource"
+	}
+
+	namePart := sanitizeHelmName(name)
+	if namePart == "" {
+		return kindPart + ".yaml"
+	}
+
+	return fmt.Sprintf("%s-%s.yaml", kindPart, namePart)
+}
+
+// uniqueResourceFilename returns filename, or filename with a numeric suffix
+// inserted before its extension if usedFilenames shows it was already
+// returned by an earlier call in this splitResourceTemplates run. Two
+// resources of the same Kind whose name sanitizes to the same string (e.g.
+// "my-app" and "my_app") would otherwise collide on resourceFilename and
+// silently overwrite each other under templates/.
+func uniqueResourceFilename(filename string, usedFilenames map[string]int) string {
+	usedFilenames[filename]++
+	if usedFilenames[filename] == 1 {
+		return filename
+	}
+
+	ext := filepath.Ext(filename)
+	base := strings.TrimSuffix(filename, ext)
+	return fmt.Sprintf("%s-%d%s", base, usedFilenames[filename], ext)
+}
+
+// mergeChartLabels ensures the resource has a metadata.labels mapping and
+// seeds it with chartLabelsPlaceholder, later swapped for the chart's common
+// labels include.
+func mergeChartLabels(docNode *yaml.Node) {
+	metadata := findChild(docNode, "metadata")
+	if metadata == nil || metadata.Kind != yaml.MappingNode {
+		return
+	}
+
+	labels := findChild(metadata, "labels")
+	if labels == nil {
+		metadata.Content = append(metadata.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "labels"},
+			&yaml.Node{Kind: yaml.MappingNode},
+		)
+		labels = metadata.Content[len(metadata.Content)-1]
+	}
+
+	placeholder := []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: chartLabelsPlaceholder},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: ""},
+	}
+	labels.Content = append(placeholder, labels.Content...)
+}
+
+// clusterScopedKinds lists the well-known k8s kinds that live outside any
+// namespace. Kubernetes rejects (or silently ignores) a "namespace" field on
+// these, so templatizeNamespace must never add or rewrite one here.
+var clusterScopedKinds = map[string]bool{
+	"Namespace":                      true,
+	"Node":                           true,
+	"PersistentVolume":               true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"CustomResourceDefinition":       true,
+	"StorageClass":                   true,
+	"PriorityClass":                  true,
+	"VolumeAttachment":               true,
+	"CSIDriver":                      true,
+	"CSINode":                        true,
+	"MutatingWebhookConfiguration":   true,
+	"ValidatingWebhookConfiguration": true,
+	"PodSecurityPolicy":              true,
+	"APIService":                     true,
+}
+
+// templatizeNamespace rewrites metadata.namespace to track the release
+// namespace, falling back to the chart's global.namespace value default.
+func templatizeNamespace(docNode *yaml.Node) {
+	metadata := findChild(docNode, "metadata")
+	if metadata == nil || metadata.Kind != yaml.MappingNode {
+		return
+	}
+
+	namespace := findChild(metadata, "namespace")
+	if namespace == nil {
+		metadata.Content = append(metadata.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "namespace"},
+			&yaml.Node{Kind: yaml.ScalarNode},
+		)
+		namespace = metadata.Content[len(metadata.Content)-1]
+	}
+
+	setTemplateScalar(namespace, `{{ .Release.Namespace | default .Values.global.namespace }}`, false)
+}
+
+// helpersTplContent is the chart's _helpers.tpl, defining the name and
+// label helpers every split resource template includes.
+const helpersTplContent = `{{/* Expand the name of the chart. */}}
+{{- define "chart.name" -}}
+{{- default .Chart.Name .Values.nameOverride | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
+{{/* Create a default fully qualified app name. */}}
+{{- define "chart.fullname" -}}
+{{- if .Values.fullnameOverride -}}
+{{- .Values.fullnameOverride | trunc 63 | trimSuffix "-" -}}
+{{- else -}}
+{{- $name := default .Chart.Name .Values.nameOverride -}}
+{{- if contains $name .Release.Name -}}
+{{- .Release.Name | trunc 63 | trimSuffix "-" -}}
+{{- else -}}
+{{- printf "%s-%s" .Release.Name $name | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
+
+{{/* Common labels */}}
+{{- define "chart.labels" -}}
+helm.sh/chart: {{ .Chart.Name }}-{{ .Chart.Version | replace "+" "_" | trunc 63 | trimSuffix "-" }}
+{{ include "chart.selectorLabels" . }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end -}}
+
+{{/* Selector labels */}}
+{{- define "chart.selectorLabels" -}}
+app.kubernetes.io/name: {{ include "chart.name" . }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end -}}
+`
@@ -0,0 +1,108 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitResourceTemplates(t *testing.T) {
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 1
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: web-reader
+rules: []
+`
+
+	templates, err := splitResourceTemplates(manifest)
+	if err != nil {
+		t.Fatalf("splitResourceTemplates returned error: %v", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected 2 resource templates, got %d", len(templates))
+	}
+
+	byFilename := make(map[string]resourceTemplate, len(templates))
+	for _, tmpl := range templates {
+		byFilename[tmpl.Filename] = tmpl
+	}
+
+	deployment, ok := byFilename["deployment-web.yaml"]
+	if !ok {
+		t.Fatalf("expected deployment-web.yaml, got filenames: %#v", byFilename)
+	}
+	if !strings.Contains(deployment.Content, `{{- include "chart.labels" . | nindent 8 }}`) {
+		t.Errorf("expected deployment template to include chart.labels, got:\n%s", deployment.Content)
+	}
+	if !strings.Contains(deployment.Content, `{{ .Release.Namespace | default .Values.global.namespace }}`) {
+		t.Errorf("expected deployment template's namespace to be templated, got:\n%s", deployment.Content)
+	}
+
+	clusterRole, ok := byFilename["clusterrole-web-reader.yaml"]
+	if !ok {
+		t.Fatalf("expected clusterrole-web-reader.yaml, got filenames: %#v", byFilename)
+	}
+	if strings.Contains(clusterRole.Content, "namespace:") {
+		t.Errorf("cluster-scoped ClusterRole must not get a namespace field, got:\n%s", clusterRole.Content)
+	}
+}
+
+func TestSplitResourceTemplatesDedupesCollidingFilenames(t *testing.T) {
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+spec:
+  replicas: 1
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my_app
+spec:
+  replicas: 1
+`
+
+	templates, err := splitResourceTemplates(manifest)
+	if err != nil {
+		t.Fatalf("splitResourceTemplates returned error: %v", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected 2 resource templates, got %d", len(templates))
+	}
+
+	filenames := map[string]bool{templates[0].Filename: true, templates[1].Filename: true}
+	if len(filenames) != 2 {
+		t.Fatalf("expected resources with colliding sanitized names to get distinct filenames, got: %#v", templates)
+	}
+	if !filenames["deployment-my-app.yaml"] {
+		t.Errorf("expected the first resource to keep the unsuffixed filename, got: %#v", templates)
+	}
+	if !filenames["deployment-my-app-2.yaml"] {
+		t.Errorf("expected the colliding resource to get a numeric suffix, got: %#v", templates)
+	}
+}
+
+func TestResourceFilename(t *testing.T) {
+	cases := []struct {
+		kind, name, want string
+	}{
+		{"Deployment", "web", "deployment-web.yaml"},
+		{"Service", "web-svc", "service-web-svc.yaml"},
+		{"", "web", "resource-web.yaml"},
+	}
+
+	for _, c := range cases {
+		if got := resourceFilename(c.kind, c.name); got != c.want {
+			t.Errorf("resourceFilename(%q, %q) = %q, want %q", c.kind, c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package converter
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// splitYAMLDocuments splits a multi-document YAML string on "---"
+// separators, the same way a rendered k8s manifest concatenates resources.
+func splitYAMLDocuments(manifest string) []string {
+	rawDocs := strings.Split(manifest, "\n---\n")
+
+	docs := make([]string, 0, len(rawDocs))
+	for _, doc := range rawDocs {
+		trimmed := strings.TrimSpace(doc)
+		if trimmed == "" {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// rawTemplateMarker prefixes a setTemplateScalar value written for a raw
+// (non-string) field, e.g. a FieldRule.Numeric replica count, so the quotes
+// yaml.v3 is forced to add around any scalar starting with "{{" (YAML
+// reserves '{' as a flow-mapping indicator, so a plain scalar can never
+// start with it) can be stripped back out by stripRawTemplateMarkers once
+// the whole document has been marshaled. Without this, a numeric field
+// would render as a quoted string - e.g. replicas: "2" - which the API
+// server rejects.
+const rawTemplateMarker = "__MESHKIT_RAW__"
+
+var rawTemplateMarkerLine = regexp.MustCompile(`"` + rawTemplateMarker + `(.*?)"`)
+
+// setTemplateScalar rewrites node in place into a scalar containing
+// template, so a manifest stays valid YAML once Helm's template engine
+// resolves it at render time. raw must only be set for fields whose
+// rendered value should stay a bare YAML scalar (e.g. an int or bool)
+// rather than a quoted string.
+func setTemplateScalar(node *yaml.Node, template string, raw bool) {
+	node.Kind = yaml.ScalarNode
+	node.Tag = "!!str"
+	node.Style = yaml.DoubleQuotedStyle
+	node.Content = nil
+
+	if raw {
+		node.Value = rawTemplateMarker + template
+		return
+	}
+	node.Value = template
+}
+
+// stripRawTemplateMarkers strips the quotes yaml.Marshal was forced to add
+// around any setTemplateScalar(..., raw=true) value. Run once over a
+// document's marshaled output, after every field in it has been rewritten.
+func stripRawTemplateMarkers(marshaled string) string {
+	return rawTemplateMarkerLine.ReplaceAllString(marshaled, `$1`)
+}